@@ -17,6 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	defaultpolicy "k8s.io/kubernetes/pkg/api/v1/defaults"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/intstr"
@@ -62,6 +66,8 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 		func(obj *ContainerPort) {
 			if obj.Protocol == "" {
 				obj.Protocol = ProtocolTCP
+			} else {
+				obj.Protocol = normalizeProtocol(obj.Protocol)
 			}
 		},
 		func(obj *Container) {
@@ -69,7 +75,7 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 				_, tag := parsers.ParseImageName(obj.Image)
 				// Check image tag
 
-				if tag == "latest" {
+				if defaultpolicy.Current().AlwaysPullLatest || tag == "latest" {
 					obj.ImagePullPolicy = PullAlways
 				} else {
 					obj.ImagePullPolicy = PullIfNotPresent
@@ -81,7 +87,10 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 		},
 		func(obj *ServiceSpec) {
 			if obj.SessionAffinity == "" {
-				obj.SessionAffinity = ServiceAffinityNone
+				obj.SessionAffinity = ServiceAffinity(defaultpolicy.Current().DefaultServiceSessionAffinity)
+				if obj.SessionAffinity == "" {
+					obj.SessionAffinity = ServiceAffinityNone
+				}
 			}
 			if obj.Type == "" {
 				obj.Type = ServiceTypeClusterIP
@@ -90,6 +99,8 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 				sp := &obj.Ports[i]
 				if sp.Protocol == "" {
 					sp.Protocol = ProtocolTCP
+				} else {
+					sp.Protocol = normalizeProtocol(sp.Protocol)
 				}
 				if sp.TargetPort == intstr.FromInt(0) || sp.TargetPort == intstr.FromString("") {
 					sp.TargetPort = intstr.FromInt(int(sp.Port))
@@ -115,38 +126,84 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 			}
 		},
 		func(obj *PodSpec) {
+			policy := defaultpolicy.Current()
 			if obj.DNSPolicy == "" {
-				obj.DNSPolicy = DNSClusterFirst
+				obj.DNSPolicy = DNSPolicy(policy.DefaultDNSPolicy)
+				if obj.DNSPolicy == "" {
+					obj.DNSPolicy = DNSClusterFirst
+				}
 			}
 			if obj.RestartPolicy == "" {
 				obj.RestartPolicy = RestartPolicyAlways
 			}
-			if obj.HostNetwork {
-				defaultHostNetworkPorts(&obj.Containers)
-			}
 			if obj.SecurityContext == nil {
 				obj.SecurityContext = &PodSecurityContext{}
 			}
+			if secDefaults := defaultpolicy.CurrentSecurityDefaults(); secDefaults.Enabled {
+				defaultPodSecurityContext(obj.SecurityContext, secDefaults)
+				for i := range obj.Containers {
+					defaultContainerSecurityContext(&obj.Containers[i], secDefaults)
+				}
+				for i := range obj.InitContainers {
+					defaultContainerSecurityContext(&obj.InitContainers[i], secDefaults)
+				}
+			}
 			if obj.TerminationGracePeriodSeconds == nil {
 				period := int64(DefaultTerminationGracePeriodSeconds)
+				if policy.DefaultTerminationGracePeriodSeconds != nil {
+					period = *policy.DefaultTerminationGracePeriodSeconds
+				}
 				obj.TerminationGracePeriodSeconds = &period
 			}
+			// Reconcile the legacy HostNetwork flag with NetworkMode: either one
+			// may have been set by the caller. Only derive one from the other
+			// when just one of the two was actually supplied; if the caller set
+			// NetworkMode to something other than Host and also set
+			// HostNetwork=true, leave both alone instead of silently resolving
+			// the conflict here; ValidateNetworkModeConsistency reports it.
 			if obj.NetworkMode == "" {
-				obj.NetworkMode = DefaultPodNetworkMode
+				if obj.HostNetwork {
+					obj.NetworkMode = NetworkModeHost
+				} else {
+					obj.NetworkMode = DefaultPodNetworkMode
+				}
+				obj.HostNetwork = obj.NetworkMode == NetworkModeHost
+			} else if !obj.HostNetwork {
+				obj.HostNetwork = obj.NetworkMode == NetworkModeHost
+			}
+			if obj.NetworkMode == NetworkModeCNI {
+				if obj.NetworkModeConfig == nil {
+					obj.NetworkModeConfig = &NetworkModeConfig{}
+				}
+				if obj.NetworkModeConfig.CNIName == "" {
+					obj.NetworkModeConfig.CNIName = defaultCNIName
+				}
+			}
+			if obj.NetworkMode == NetworkModeHost {
+				defaultHostNetworkPorts(&obj.Containers)
+			}
+			if len(obj.ImagePullSecrets) == 0 && len(policy.DefaultImagePullSecrets) > 0 {
+				for _, name := range policy.DefaultImagePullSecrets {
+					obj.ImagePullSecrets = append(obj.ImagePullSecrets, LocalObjectReference{Name: name})
+				}
 			}
 		},
 		func(obj *Probe) {
+			timeout, period, success, failure := int32(1), int32(10), int32(1), int32(3)
+			if params := defaultpolicy.Current().DefaultProbeParams; params != nil {
+				timeout, period, success, failure = params.TimeoutSeconds, params.PeriodSeconds, params.SuccessThreshold, params.FailureThreshold
+			}
 			if obj.TimeoutSeconds == 0 {
-				obj.TimeoutSeconds = 1
+				obj.TimeoutSeconds = timeout
 			}
 			if obj.PeriodSeconds == 0 {
-				obj.PeriodSeconds = 10
+				obj.PeriodSeconds = period
 			}
 			if obj.SuccessThreshold == 0 {
-				obj.SuccessThreshold = 1
+				obj.SuccessThreshold = success
 			}
 			if obj.FailureThreshold == 0 {
-				obj.FailureThreshold = 3
+				obj.FailureThreshold = failure
 			}
 		},
 		func(obj *Secret) {
@@ -166,6 +223,14 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 			if obj.Status.Phase == "" {
 				obj.Status.Phase = ClaimPending
 			}
+			if _, exists := obj.Spec.Resources.Requests[ResourceStorage]; !exists && limitRangeLister != nil {
+				if request, ok := defaultStorageRequestFromLimitRanges(limitRangeLister, obj.Namespace); ok {
+					if obj.Spec.Resources.Requests == nil {
+						obj.Spec.Resources.Requests = make(ResourceList)
+					}
+					obj.Spec.Resources.Requests[ResourceStorage] = request
+				}
+			}
 		},
 		func(obj *ISCSIVolumeSource) {
 			if obj.ISCSIInterface == "" {
@@ -179,6 +244,8 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 					ep := &ss.Ports[i]
 					if ep.Protocol == "" {
 						ep.Protocol = ProtocolTCP
+					} else {
+						ep.Protocol = normalizeProtocol(ep.Protocol)
 					}
 				}
 			}
@@ -216,8 +283,9 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 			}
 		},
 		func(obj *LimitRangeItem) {
-			// for container limits, we apply default values
-			if obj.Type == LimitTypeContainer {
+			// For container, pod, and PersistentVolumeClaim limits, we apply
+			// default values using the same inference rules.
+			if obj.Type == LimitTypeContainer || obj.Type == LimitTypePod || obj.Type == LimitTypePersistentVolumeClaim {
 
 				if obj.Default == nil {
 					obj.Default = make(ResourceList)
@@ -251,9 +319,89 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 				obj.Data = make(map[string]string)
 			}
 		},
+		func(obj *DaemonSet) {
+			labels := obj.Spec.Template.Labels
+			if labels != nil && obj.Spec.Selector == nil {
+				obj.Spec.Selector = &unversioned.LabelSelector{MatchLabels: labels}
+			}
+			defaultRollingUpdateDaemonSetStrategy(&obj.Spec.UpdateStrategy)
+		},
+		func(obj *Deployment) {
+			if obj.Spec.Replicas == nil {
+				obj.Spec.Replicas = new(int32)
+				*obj.Spec.Replicas = 1
+			}
+			labels := obj.Spec.Template.Labels
+			if labels != nil && obj.Spec.Selector == nil {
+				obj.Spec.Selector = &unversioned.LabelSelector{MatchLabels: labels}
+			}
+			defaultRollingUpdateDeploymentStrategy(&obj.Spec.Strategy)
+			if obj.Spec.RevisionHistoryLimit == nil {
+				obj.Spec.RevisionHistoryLimit = new(int32)
+				*obj.Spec.RevisionHistoryLimit = 10
+			}
+			if obj.Spec.ProgressDeadlineSeconds == nil {
+				obj.Spec.ProgressDeadlineSeconds = new(int32)
+				*obj.Spec.ProgressDeadlineSeconds = 600
+			}
+		},
+		func(obj *StatefulSet) {
+			if obj.Spec.Replicas == nil {
+				obj.Spec.Replicas = new(int32)
+				*obj.Spec.Replicas = 1
+			}
+			labels := obj.Spec.Template.Labels
+			if labels != nil && obj.Spec.Selector == nil {
+				obj.Spec.Selector = &unversioned.LabelSelector{MatchLabels: labels}
+			}
+			if obj.Spec.UpdateStrategy.Type == "" {
+				obj.Spec.UpdateStrategy.Type = RollingUpdateStatefulSetStrategyType
+			}
+		},
 	)
 }
 
+// defaultRollingUpdateDeploymentStrategy defaults a Deployment's rolling update
+// parameters in place, allocating RollingUpdate when the strategy is (or defaults
+// to) RollingUpdate.
+func defaultRollingUpdateDeploymentStrategy(strategy *DeploymentStrategy) {
+	if strategy.Type == "" {
+		strategy.Type = RollingUpdateDeploymentStrategyType
+	}
+	if strategy.Type != RollingUpdateDeploymentStrategyType {
+		return
+	}
+	if strategy.RollingUpdate == nil {
+		strategy.RollingUpdate = &RollingUpdateDeployment{}
+	}
+	if strategy.RollingUpdate.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(1)
+		strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+	if strategy.RollingUpdate.MaxSurge == nil {
+		maxSurge := intstr.FromString("25%")
+		strategy.RollingUpdate.MaxSurge = &maxSurge
+	}
+}
+
+// defaultRollingUpdateDaemonSetStrategy defaults a DaemonSet's rolling update
+// parameters in place, mirroring defaultRollingUpdateDeploymentStrategy.
+func defaultRollingUpdateDaemonSetStrategy(strategy *DaemonSetUpdateStrategy) {
+	if strategy.Type == "" {
+		strategy.Type = RollingUpdateDaemonSetStrategyType
+	}
+	if strategy.Type != RollingUpdateDaemonSetStrategyType {
+		return
+	}
+	if strategy.RollingUpdate == nil {
+		strategy.RollingUpdate = &RollingUpdateDaemonSet{}
+	}
+	if strategy.RollingUpdate.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(1)
+		strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+}
+
 // With host networking default all container ports to host ports.
 func defaultHostNetworkPorts(containers *[]Container) {
 	for i := range *containers {
@@ -264,3 +412,83 @@ func defaultHostNetworkPorts(containers *[]Container) {
 		}
 	}
 }
+
+// defaultPodSecurityContext fills sc from policy wherever the caller left a
+// field unset. It never overwrites a value the caller already provided.
+// Callers only reach this once policy.Enabled is true, so each field below
+// falls back to its hardened default rather than staying unset, matching
+// the container-level hardening in defaultContainerSecurityContext.
+func defaultPodSecurityContext(sc *PodSecurityContext, policy *defaultpolicy.SecurityDefaults) {
+	if sc.RunAsNonRoot == nil {
+		nonRoot := true
+		if policy.RunAsNonRoot != nil {
+			nonRoot = *policy.RunAsNonRoot
+		}
+		sc.RunAsNonRoot = &nonRoot
+	}
+	if sc.SeccompProfile == "" {
+		profile := SeccompProfileRuntimeDefault
+		if policy.SeccompProfile != "" {
+			profile = policy.SeccompProfile
+		}
+		sc.SeccompProfile = profile
+	}
+	if sc.FSGroupChangePolicy == nil {
+		change := FSGroupChangeOnRootMismatch
+		if policy.FSGroupChangePolicy != "" {
+			change = PodFSGroupChangePolicy(policy.FSGroupChangePolicy)
+		}
+		sc.FSGroupChangePolicy = &change
+	}
+}
+
+// defaultContainerSecurityContext fills c.SecurityContext from policy wherever
+// the caller left a field unset, allocating SecurityContext and Capabilities
+// as needed. It never overwrites a value the caller already provided. Callers
+// only reach this once policy.Enabled is true.
+func defaultContainerSecurityContext(c *Container, policy *defaultpolicy.SecurityDefaults) {
+	if c.SecurityContext == nil {
+		c.SecurityContext = &SecurityContext{}
+	}
+	sc := c.SecurityContext
+	if sc.AllowPrivilegeEscalation == nil {
+		allow := false
+		if policy.AllowPrivilegeEscalation != nil {
+			allow = *policy.AllowPrivilegeEscalation
+		}
+		sc.AllowPrivilegeEscalation = &allow
+	}
+	if sc.ReadOnlyRootFilesystem == nil && policy.ReadOnlyRootFilesystem != nil {
+		readOnly := *policy.ReadOnlyRootFilesystem
+		sc.ReadOnlyRootFilesystem = &readOnly
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &Capabilities{}
+	}
+	if len(sc.Capabilities.Drop) == 0 {
+		sc.Capabilities.Drop = []Capability{"ALL"}
+	}
+	if len(sc.Capabilities.Add) == 0 {
+		for _, capability := range policy.AddCapabilities {
+			sc.Capabilities.Add = append(sc.Capabilities.Add, Capability(capability))
+		}
+	}
+}
+
+// normalizeProtocol uppercases protocol and maps it onto the known Protocol
+// constants (ProtocolTCP, ProtocolUDP) regardless of the case the user
+// submitted it in (e.g. "tcp", "Udp"). ProtocolSCTP is deliberately not
+// normalized here: validation does not yet accept it, so normalizing "sctp"
+// to "SCTP" would only swap one rejected spelling for another. Values that
+// don't match a known protocol, case-insensitively, are returned unchanged so
+// validation can reject them with a clear error.
+func normalizeProtocol(protocol Protocol) Protocol {
+	switch Protocol(strings.ToUpper(string(protocol))) {
+	case ProtocolTCP:
+		return ProtocolTCP
+	case ProtocolUDP:
+		return ProtocolUDP
+	default:
+		return protocol
+	}
+}
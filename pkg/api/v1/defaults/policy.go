@@ -0,0 +1,114 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package defaults makes the defaulting behavior of the v1 API parameterizable
+// by a cluster operator, instead of hard-coded in pkg/api/v1/defaults.go. A
+// DefaultingPolicy is parsed with LoadPolicy and installed with SetPolicy; the
+// scheme-level defaulting funcs consult the active policy through Current.
+// LoadPolicy only parses and does not install its result; until SetPolicy is
+// called, Current returns the zero-value policy.
+package defaults
+
+import (
+	"encoding/json"
+	"sync"
+
+	utilyaml "k8s.io/kubernetes/pkg/util/yaml"
+)
+
+// ProbeDefaults overrides the built-in Probe timing defaults.
+type ProbeDefaults struct {
+	TimeoutSeconds   int32 `json:"timeoutSeconds,omitempty"`
+	PeriodSeconds    int32 `json:"periodSeconds,omitempty"`
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// DefaultingPolicy parameterizes the defaulting functions registered in
+// pkg/api/v1/defaults.go. The zero value reproduces the behavior those
+// functions had before policies existed, so a cluster that never loads a
+// policy sees no change.
+type DefaultingPolicy struct {
+	// AlwaysPullLatest, when true, defaults every container's ImagePullPolicy to
+	// Always regardless of image tag. When false (the zero value), only images
+	// tagged ":latest" default to Always, matching the original hard-coded rule.
+	AlwaysPullLatest bool `json:"alwaysPullLatest,omitempty"`
+
+	// DefaultTerminationGracePeriodSeconds overrides the grace period applied to
+	// Pods that do not specify one. Defaults to DefaultTerminationGracePeriodSeconds
+	// when nil.
+	DefaultTerminationGracePeriodSeconds *int64 `json:"defaultTerminationGracePeriodSeconds,omitempty"`
+
+	// DefaultDNSPolicy overrides the DNSPolicy applied to Pods that do not specify
+	// one. Defaults to DNSClusterFirst when empty.
+	DefaultDNSPolicy string `json:"defaultDNSPolicy,omitempty"`
+
+	// DefaultServiceSessionAffinity overrides the SessionAffinity applied to
+	// Services that do not specify one. Defaults to ServiceAffinityNone when empty.
+	DefaultServiceSessionAffinity string `json:"defaultServiceSessionAffinity,omitempty"`
+
+	// DefaultImagePullSecrets are applied to Pods that do not specify any
+	// ImagePullSecrets of their own.
+	DefaultImagePullSecrets []string `json:"defaultImagePullSecrets,omitempty"`
+
+	// DefaultProbeParams overrides the built-in Probe timing defaults. Defaults to
+	// timeout=1s, period=10s, successThreshold=1, failureThreshold=3 when nil.
+	DefaultProbeParams *ProbeDefaults `json:"defaultProbeParams,omitempty"`
+}
+
+// PolicyRegistry holds the active, live-reloadable DefaultingPolicy. Until a
+// policy is loaded, the package registry holds a zero-value DefaultingPolicy,
+// which reproduces the original hard-coded defaulting behavior.
+type PolicyRegistry struct {
+	mu     sync.RWMutex
+	policy *DefaultingPolicy
+}
+
+var defaultRegistry = &PolicyRegistry{policy: &DefaultingPolicy{}}
+
+// SetPolicy installs policy as the active cluster-wide policy. It is safe to
+// call concurrently with Current; a reload takes effect immediately for any
+// defaulting performed afterwards. Passing nil restores the original
+// hard-coded behavior.
+func SetPolicy(policy *DefaultingPolicy) {
+	if policy == nil {
+		policy = &DefaultingPolicy{}
+	}
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	defaultRegistry.policy = policy
+}
+
+// Current returns the active cluster-scoped policy. Scheme-level defaulting
+// funcs consult this for every object they default.
+func Current() *DefaultingPolicy {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	return defaultRegistry.policy
+}
+
+// LoadPolicy parses a DefaultingPolicy from YAML or JSON.
+func LoadPolicy(data []byte) (*DefaultingPolicy, error) {
+	jsonData, err := utilyaml.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	policy := &DefaultingPolicy{}
+	if err := json.Unmarshal(jsonData, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"testing"
+)
+
+func TestNoPolicyLoadedMatchesZeroValue(t *testing.T) {
+	// Callers that never call SetPolicy must see the same zero-value policy
+	// that the original hard-coded defaults.go behavior assumed.
+	policy := Current()
+	if policy == nil {
+		t.Fatal("expected a non-nil default policy")
+	}
+	if policy.AlwaysPullLatest {
+		t.Errorf("expected AlwaysPullLatest to default to false")
+	}
+	if policy.DefaultTerminationGracePeriodSeconds != nil {
+		t.Errorf("expected DefaultTerminationGracePeriodSeconds to default to nil")
+	}
+	if policy.DefaultDNSPolicy != "" {
+		t.Errorf("expected DefaultDNSPolicy to default to empty")
+	}
+	if policy.DefaultServiceSessionAffinity != "" {
+		t.Errorf("expected DefaultServiceSessionAffinity to default to empty")
+	}
+	if len(policy.DefaultImagePullSecrets) != 0 {
+		t.Errorf("expected DefaultImagePullSecrets to default to empty")
+	}
+	if policy.DefaultProbeParams != nil {
+		t.Errorf("expected DefaultProbeParams to default to nil")
+	}
+}
+
+func TestSetPolicyReload(t *testing.T) {
+	defer SetPolicy(nil)
+
+	SetPolicy(&DefaultingPolicy{DefaultDNSPolicy: "Default"})
+	if got := Current().DefaultDNSPolicy; got != "Default" {
+		t.Errorf("expected reloaded policy to take effect immediately, got %q", got)
+	}
+
+	SetPolicy(nil)
+	if got := Current().DefaultDNSPolicy; got != "" {
+		t.Errorf("expected SetPolicy(nil) to restore original behavior, got %q", got)
+	}
+}
+
+func TestLoadPolicyYAML(t *testing.T) {
+	data := []byte("alwaysPullLatest: true\ndefaultDNSPolicy: Default\n")
+	policy, err := LoadPolicy(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !policy.AlwaysPullLatest {
+		t.Errorf("expected alwaysPullLatest: true to parse")
+	}
+	if policy.DefaultDNSPolicy != "Default" {
+		t.Errorf("expected defaultDNSPolicy: Default to parse, got %q", policy.DefaultDNSPolicy)
+	}
+}
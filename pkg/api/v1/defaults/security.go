@@ -0,0 +1,83 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import "sync"
+
+// SecurityDefaults parameterizes the PodSecurityContext and container
+// SecurityContext defaulting performed by pkg/api/v1/defaults.go. The zero
+// value leaves Enabled false, so a cluster that never calls
+// SetSecurityDefaults sees Pods get only the original empty
+// PodSecurityContext{} they got before this config existed.
+type SecurityDefaults struct {
+	// Enabled opts the cluster into the pod- and container-level hardening
+	// defaults below. It defaults to false so that merely linking this
+	// package, or decoding a SecurityDefaults nobody configured, changes no
+	// existing behavior; an operator must explicitly turn it on.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RunAsNonRoot overrides the hardcoded RunAsNonRoot=true applied to
+	// PodSecurityContext.RunAsNonRoot when unset and Enabled is true.
+	RunAsNonRoot *bool `json:"runAsNonRoot,omitempty"`
+
+	// SeccompProfile overrides the hardcoded "RuntimeDefault" applied to
+	// PodSecurityContext.SeccompProfile when unset and Enabled is true.
+	SeccompProfile string `json:"seccompProfile,omitempty"`
+
+	// FSGroupChangePolicy overrides the hardcoded "OnRootMismatch" applied to
+	// PodSecurityContext.FSGroupChangePolicy when unset and Enabled is true.
+	FSGroupChangePolicy string `json:"fsGroupChangePolicy,omitempty"`
+
+	// AllowPrivilegeEscalation is applied to each container's
+	// SecurityContext.AllowPrivilegeEscalation when unset and Enabled is
+	// true. Defaults to false when this field itself is nil.
+	AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation,omitempty"`
+
+	// ReadOnlyRootFilesystem is applied to each container's
+	// SecurityContext.ReadOnlyRootFilesystem when unset and Enabled is true.
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// AddCapabilities are appended to each container's
+	// SecurityContext.Capabilities.Add when Enabled is true and the
+	// container specifies none of its own, alongside the "ALL" drop that is
+	// applied when the container drops no capabilities itself.
+	AddCapabilities []string `json:"addCapabilities,omitempty"`
+}
+
+var (
+	securityMu       sync.RWMutex
+	securityDefaults = &SecurityDefaults{}
+)
+
+// SetSecurityDefaults installs defaults as the active cluster-wide
+// SecurityDefaults. Passing nil restores the original behavior (an empty
+// PodSecurityContext{} with no further defaulting).
+func SetSecurityDefaults(defaults *SecurityDefaults) {
+	if defaults == nil {
+		defaults = &SecurityDefaults{}
+	}
+	securityMu.Lock()
+	defer securityMu.Unlock()
+	securityDefaults = defaults
+}
+
+// CurrentSecurityDefaults returns the active cluster-wide SecurityDefaults.
+func CurrentSecurityDefaults() *SecurityDefaults {
+	securityMu.RLock()
+	defer securityMu.RUnlock()
+	return securityDefaults
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "testing"
+
+func TestNormalizeProtocol(t *testing.T) {
+	tests := []struct {
+		in       Protocol
+		expected Protocol
+	}{
+		{"tcp", ProtocolTCP},
+		{"Tcp", ProtocolTCP},
+		{"TCP", ProtocolTCP},
+		{"udp", ProtocolUDP},
+		{"Udp", ProtocolUDP},
+		{"sctp", "sctp"},
+		{"Sctp", "Sctp"},
+		{"SCTP", ProtocolSCTP},
+		{"bogus", "bogus"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := normalizeProtocol(test.in); got != test.expected {
+			t.Errorf("normalizeProtocol(%q) = %q, want %q", test.in, got, test.expected)
+		}
+	}
+}
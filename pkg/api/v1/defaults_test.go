@@ -0,0 +1,436 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	. "k8s.io/kubernetes/pkg/api/v1"
+	defaultpolicy "k8s.io/kubernetes/pkg/api/v1/defaults"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/intstr"
+)
+
+func roundTrip(t *testing.T, obj runtime.Object) runtime.Object {
+	data, err := runtime.Encode(api.Codecs.LegacyCodec(SchemeGroupVersion), obj)
+	if err != nil {
+		t.Errorf("%v\n %#v", err, obj)
+		return nil
+	}
+	obj2, err := runtime.Decode(api.Codecs.UniversalDecoder(), data)
+	if err != nil {
+		t.Errorf("%v\nData: %s\nSource: %#v", err, string(data), obj)
+		return nil
+	}
+	// obj2 is in the internal version; convert it back to obj's v1 type.
+	obj3 := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	if err := api.Scheme.Convert(obj2, obj3); err != nil {
+		t.Errorf("%v\nSource: %#v", err, obj2)
+		return nil
+	}
+	return obj3
+}
+
+func TestSetDefaultDeployment(t *testing.T) {
+	original := &Deployment{
+		Spec: DeploymentSpec{
+			Template: PodTemplateSpec{
+				ObjectMeta: ObjectMeta{Labels: map[string]string{"k": "v"}},
+			},
+		},
+	}
+	got := roundTrip(t, runtime.Object(original))
+	if got == nil {
+		return
+	}
+	obj2 := got.(*Deployment)
+	if obj2.Spec.Replicas == nil || *obj2.Spec.Replicas != 1 {
+		t.Errorf("expected replicas default to 1, got %v", obj2.Spec.Replicas)
+	}
+	if obj2.Spec.Selector == nil || obj2.Spec.Selector.MatchLabels["k"] != "v" {
+		t.Errorf("expected selector defaulted from template labels, got %v", obj2.Spec.Selector)
+	}
+	if obj2.Spec.Strategy.Type != RollingUpdateDeploymentStrategyType {
+		t.Errorf("expected strategy type RollingUpdate, got %v", obj2.Spec.Strategy.Type)
+	}
+	if obj2.Spec.Strategy.RollingUpdate == nil {
+		t.Fatalf("expected RollingUpdate to be allocated")
+	}
+	if *obj2.Spec.Strategy.RollingUpdate.MaxUnavailable != intstr.FromInt(1) {
+		t.Errorf("expected MaxUnavailable default of 1, got %v", obj2.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	}
+	if *obj2.Spec.Strategy.RollingUpdate.MaxSurge != intstr.FromString("25%") {
+		t.Errorf("expected MaxSurge default of 25%%, got %v", obj2.Spec.Strategy.RollingUpdate.MaxSurge)
+	}
+	if obj2.Spec.RevisionHistoryLimit == nil || *obj2.Spec.RevisionHistoryLimit != 10 {
+		t.Errorf("expected RevisionHistoryLimit default of 10, got %v", obj2.Spec.RevisionHistoryLimit)
+	}
+	if obj2.Spec.ProgressDeadlineSeconds == nil || *obj2.Spec.ProgressDeadlineSeconds != 600 {
+		t.Errorf("expected ProgressDeadlineSeconds default of 600, got %v", obj2.Spec.ProgressDeadlineSeconds)
+	}
+}
+
+func TestSetDefaultDaemonSet(t *testing.T) {
+	obj := &DaemonSet{
+		Spec: DaemonSetSpec{
+			Template: PodTemplateSpec{
+				ObjectMeta: ObjectMeta{Labels: map[string]string{"k": "v"}},
+			},
+		},
+	}
+	got := roundTrip(t, runtime.Object(obj))
+	if got == nil {
+		return
+	}
+	obj2 := got.(*DaemonSet)
+	if obj2.Spec.Selector == nil || obj2.Spec.Selector.MatchLabels["k"] != "v" {
+		t.Errorf("expected selector defaulted from template labels, got %v", obj2.Spec.Selector)
+	}
+	if obj2.Spec.UpdateStrategy.Type != RollingUpdateDaemonSetStrategyType {
+		t.Errorf("expected update strategy default of RollingUpdate, got %v", obj2.Spec.UpdateStrategy.Type)
+	}
+	if obj2.Spec.UpdateStrategy.RollingUpdate == nil || *obj2.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable != intstr.FromInt(1) {
+		t.Errorf("expected MaxUnavailable default of 1, got %v", obj2.Spec.UpdateStrategy.RollingUpdate)
+	}
+}
+
+func TestSetDefaultStatefulSet(t *testing.T) {
+	obj := &StatefulSet{
+		Spec: StatefulSetSpec{
+			Template: PodTemplateSpec{
+				ObjectMeta: ObjectMeta{Labels: map[string]string{"k": "v"}},
+			},
+		},
+	}
+	got := roundTrip(t, runtime.Object(obj))
+	if got == nil {
+		return
+	}
+	obj2 := got.(*StatefulSet)
+	if obj2.Spec.Replicas == nil || *obj2.Spec.Replicas != 1 {
+		t.Errorf("expected replicas default to 1, got %v", obj2.Spec.Replicas)
+	}
+	if obj2.Spec.Selector == nil || obj2.Spec.Selector.MatchLabels["k"] != "v" {
+		t.Errorf("expected selector defaulted from template labels, got %v", obj2.Spec.Selector)
+	}
+	if obj2.Spec.UpdateStrategy.Type != RollingUpdateStatefulSetStrategyType {
+		t.Errorf("expected update strategy default of RollingUpdate, got %v", obj2.Spec.UpdateStrategy.Type)
+	}
+}
+
+func TestSetDefaultProtocolNormalization(t *testing.T) {
+	pod := &Pod{
+		Spec: PodSpec{
+			Containers: []Container{
+				{Ports: []ContainerPort{{Protocol: "tcp"}, {Protocol: "Udp"}, {Protocol: "sctp"}}},
+			},
+		},
+	}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	ports := got.(*Pod).Spec.Containers[0].Ports
+	if ports[0].Protocol != ProtocolTCP {
+		t.Errorf("expected \"tcp\" to normalize to %v, got %v", ProtocolTCP, ports[0].Protocol)
+	}
+	if ports[1].Protocol != ProtocolUDP {
+		t.Errorf("expected \"Udp\" to normalize to %v, got %v", ProtocolUDP, ports[1].Protocol)
+	}
+	if ports[2].Protocol != "sctp" {
+		t.Errorf("expected \"sctp\" to pass through unnormalized since validation doesn't accept SCTP yet, got %v", ports[2].Protocol)
+	}
+
+	svc := &Service{
+		Spec: ServiceSpec{Ports: []ServicePort{{Port: 80, Protocol: "tcp"}}},
+	}
+	gotSvc := roundTrip(t, runtime.Object(svc))
+	if gotSvc == nil {
+		return
+	}
+	if p := gotSvc.(*Service).Spec.Ports[0].Protocol; p != ProtocolTCP {
+		t.Errorf("expected ServicePort \"tcp\" to normalize to %v, got %v", ProtocolTCP, p)
+	}
+
+	ep := &Endpoints{
+		Subsets: []EndpointSubset{{Ports: []EndpointPort{{Protocol: "udp"}}}},
+	}
+	gotEp := roundTrip(t, runtime.Object(ep))
+	if gotEp == nil {
+		return
+	}
+	if p := gotEp.(*Endpoints).Subsets[0].Ports[0].Protocol; p != ProtocolUDP {
+		t.Errorf("expected EndpointPort \"udp\" to normalize to %v, got %v", ProtocolUDP, p)
+	}
+}
+
+func TestSetDefaultNetworkModeFromHostNetwork(t *testing.T) {
+	pod := &Pod{Spec: PodSpec{HostNetwork: true}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if spec.NetworkMode != NetworkModeHost {
+		t.Errorf("expected HostNetwork=true to default NetworkMode to Host, got %v", spec.NetworkMode)
+	}
+}
+
+func TestSetDefaultHostNetworkFromNetworkMode(t *testing.T) {
+	pod := &Pod{Spec: PodSpec{NetworkMode: NetworkModeHost}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if !spec.HostNetwork {
+		t.Errorf("expected NetworkMode=Host to default HostNetwork to true")
+	}
+}
+
+func TestSetDefaultNetworkModeCNIName(t *testing.T) {
+	SetDefaultCNIName("calico")
+	defer SetDefaultCNIName("")
+
+	pod := &Pod{Spec: PodSpec{NetworkMode: NetworkModeCNI}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if spec.NetworkModeConfig == nil || spec.NetworkModeConfig.CNIName != "calico" {
+		t.Errorf("expected NetworkModeCNI to default CNIName to the cluster default, got %v", spec.NetworkModeConfig)
+	}
+}
+
+func TestSetDefaultHostNetworkConflictWithNetworkModePreserved(t *testing.T) {
+	pod := &Pod{Spec: PodSpec{HostNetwork: true, NetworkMode: NetworkModeNone}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if spec.NetworkMode != NetworkModeNone {
+		t.Errorf("expected explicit NetworkMode=None to survive defaulting, got %v", spec.NetworkMode)
+	}
+	if !spec.HostNetwork {
+		t.Errorf("expected explicit HostNetwork=true to survive defaulting rather than be silently resolved, got %v", spec.HostNetwork)
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestSetDefaultPodSecurityContextFromPolicy(t *testing.T) {
+	defaultpolicy.SetSecurityDefaults(&defaultpolicy.SecurityDefaults{
+		Enabled:             true,
+		RunAsNonRoot:        boolPtr(true),
+		SeccompProfile:      SeccompProfileRuntimeDefault,
+		FSGroupChangePolicy: string(FSGroupChangeOnRootMismatch),
+		AddCapabilities:     []string{"NET_BIND_SERVICE"},
+	})
+	defer defaultpolicy.SetSecurityDefaults(nil)
+
+	pod := &Pod{Spec: PodSpec{Containers: []Container{{}}, InitContainers: []Container{{}}}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+
+	if spec.SecurityContext == nil || spec.SecurityContext.RunAsNonRoot == nil || !*spec.SecurityContext.RunAsNonRoot {
+		t.Errorf("expected RunAsNonRoot to default to true, got %v", spec.SecurityContext)
+	}
+	if spec.SecurityContext.SeccompProfile != SeccompProfileRuntimeDefault {
+		t.Errorf("expected SeccompProfile default, got %v", spec.SecurityContext.SeccompProfile)
+	}
+	if spec.SecurityContext.FSGroupChangePolicy == nil || *spec.SecurityContext.FSGroupChangePolicy != FSGroupChangeOnRootMismatch {
+		t.Errorf("expected FSGroupChangePolicy default, got %v", spec.SecurityContext.FSGroupChangePolicy)
+	}
+
+	for _, c := range append(append([]Container{}, spec.Containers...), spec.InitContainers...) {
+		if c.SecurityContext == nil {
+			t.Fatalf("expected SecurityContext to be allocated on every container")
+		}
+		if c.SecurityContext.AllowPrivilegeEscalation == nil || *c.SecurityContext.AllowPrivilegeEscalation {
+			t.Errorf("expected AllowPrivilegeEscalation to default to false, got %v", c.SecurityContext.AllowPrivilegeEscalation)
+		}
+		if c.SecurityContext.Capabilities == nil || len(c.SecurityContext.Capabilities.Drop) != 1 || c.SecurityContext.Capabilities.Drop[0] != "ALL" {
+			t.Errorf("expected Capabilities.Drop=[ALL], got %v", c.SecurityContext.Capabilities)
+		}
+		if len(c.SecurityContext.Capabilities.Add) != 1 || c.SecurityContext.Capabilities.Add[0] != "NET_BIND_SERVICE" {
+			t.Errorf("expected Capabilities.Add from policy, got %v", c.SecurityContext.Capabilities)
+		}
+	}
+}
+
+func TestSetDefaultPodSecurityContextDoesNotOverwrite(t *testing.T) {
+	defaultpolicy.SetSecurityDefaults(&defaultpolicy.SecurityDefaults{Enabled: true, RunAsNonRoot: boolPtr(true)})
+	defer defaultpolicy.SetSecurityDefaults(nil)
+
+	userValue := false
+	pod := &Pod{Spec: PodSpec{SecurityContext: &PodSecurityContext{RunAsNonRoot: &userValue}}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if spec.SecurityContext.RunAsNonRoot == nil || *spec.SecurityContext.RunAsNonRoot {
+		t.Errorf("expected user-provided RunAsNonRoot=false to survive defaulting, got %v", spec.SecurityContext.RunAsNonRoot)
+	}
+}
+
+func TestSetDefaultPodSecurityContextNotEnabled(t *testing.T) {
+	defaultpolicy.SetSecurityDefaults(&defaultpolicy.SecurityDefaults{RunAsNonRoot: boolPtr(true)})
+	defer defaultpolicy.SetSecurityDefaults(nil)
+
+	pod := &Pod{Spec: PodSpec{Containers: []Container{{}}}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if spec.SecurityContext == nil {
+		t.Fatalf("expected the original empty PodSecurityContext{} to still be allocated")
+	}
+	if spec.SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("expected Enabled=false to skip policy defaulting, got %v", spec.SecurityContext.RunAsNonRoot)
+	}
+	if spec.Containers[0].SecurityContext != nil {
+		t.Errorf("expected Enabled=false to skip container defaulting, got %v", spec.Containers[0].SecurityContext)
+	}
+}
+
+func TestSetDefaultPodSecurityContextNoPolicyConfigured(t *testing.T) {
+	// No SetSecurityDefaults call at all: SecurityDefaults must stay at its
+	// zero value (Enabled=false), so containers come back exactly as the
+	// original defaulting behaved before this config existed.
+	pod := &Pod{Spec: PodSpec{Containers: []Container{{}}}}
+	got := roundTrip(t, runtime.Object(pod))
+	if got == nil {
+		return
+	}
+	spec := got.(*Pod).Spec
+	if spec.SecurityContext == nil {
+		t.Fatalf("expected the original empty PodSecurityContext{} to still be allocated")
+	}
+	if spec.SecurityContext.RunAsNonRoot != nil || spec.SecurityContext.SeccompProfile != "" || spec.SecurityContext.FSGroupChangePolicy != nil {
+		t.Errorf("expected no policy defaulting without a SetSecurityDefaults call, got %v", spec.SecurityContext)
+	}
+	if spec.Containers[0].SecurityContext != nil {
+		t.Errorf("expected container SecurityContext to stay nil without a SetSecurityDefaults call, got %v", spec.Containers[0].SecurityContext)
+	}
+}
+
+func TestSetDefaultLimitRangeItemPVCAndPod(t *testing.T) {
+	storageMax := resource.MustParse("10Gi")
+	memMin := resource.MustParse("64Mi")
+
+	lr := &LimitRange{
+		Spec: LimitRangeSpec{
+			Limits: []LimitRangeItem{
+				{Type: LimitTypePersistentVolumeClaim, Max: ResourceList{ResourceStorage: storageMax}},
+				{Type: LimitTypePod, Min: ResourceList{ResourceMemory: memMin}},
+			},
+		},
+	}
+	got := roundTrip(t, runtime.Object(lr))
+	if got == nil {
+		return
+	}
+	limits := got.(*LimitRange).Spec.Limits
+
+	pvcItem := limits[0]
+	if q, ok := pvcItem.Default[ResourceStorage]; !ok || q.Cmp(storageMax) != 0 {
+		t.Errorf("expected PVC limit Default[storage] to default from Max, got %v", pvcItem.Default)
+	}
+	if q, ok := pvcItem.DefaultRequest[ResourceStorage]; !ok || q.Cmp(storageMax) != 0 {
+		t.Errorf("expected PVC limit DefaultRequest[storage] to default from Default, got %v", pvcItem.DefaultRequest)
+	}
+
+	podItem := limits[1]
+	if q, ok := podItem.DefaultRequest[ResourceMemory]; !ok || q.Cmp(memMin) != 0 {
+		t.Errorf("expected Pod limit DefaultRequest[memory] to default from Min, got %v", podItem.DefaultRequest)
+	}
+}
+
+type fakeLimitRangeLister struct {
+	limitRanges []LimitRange
+}
+
+func (f *fakeLimitRangeLister) List(namespace string) ([]LimitRange, error) {
+	return f.limitRanges, nil
+}
+
+func TestSetDefaultPVCStorageRequestFromLimitRange(t *testing.T) {
+	defaultRequest := resource.MustParse("1Gi")
+	SetLimitRangeLister(&fakeLimitRangeLister{
+		limitRanges: []LimitRange{
+			{
+				Spec: LimitRangeSpec{
+					Limits: []LimitRangeItem{
+						{Type: LimitTypePersistentVolumeClaim, DefaultRequest: ResourceList{ResourceStorage: defaultRequest}},
+					},
+				},
+			},
+		},
+	})
+	defer SetLimitRangeLister(nil)
+
+	pvc := &PersistentVolumeClaim{ObjectMeta: ObjectMeta{Namespace: "default"}}
+	got := roundTrip(t, runtime.Object(pvc))
+	if got == nil {
+		return
+	}
+	obj2 := got.(*PersistentVolumeClaim)
+	if q, ok := obj2.Spec.Resources.Requests[ResourceStorage]; !ok || q.Cmp(defaultRequest) != 0 {
+		t.Errorf("expected storage request defaulted from LimitRange, got %v", obj2.Spec.Resources.Requests)
+	}
+}
+
+func TestSetDefaultPVCStorageRequestNotOverwritten(t *testing.T) {
+	defaultRequest := resource.MustParse("1Gi")
+	userRequest := resource.MustParse("5Gi")
+	SetLimitRangeLister(&fakeLimitRangeLister{
+		limitRanges: []LimitRange{
+			{
+				Spec: LimitRangeSpec{
+					Limits: []LimitRangeItem{
+						{Type: LimitTypePersistentVolumeClaim, DefaultRequest: ResourceList{ResourceStorage: defaultRequest}},
+					},
+				},
+			},
+		},
+	})
+	defer SetLimitRangeLister(nil)
+
+	pvc := &PersistentVolumeClaim{
+		Spec: PersistentVolumeClaimSpec{
+			Resources: ResourceRequirements{Requests: ResourceList{ResourceStorage: userRequest}},
+		},
+	}
+	got := roundTrip(t, runtime.Object(pvc))
+	if got == nil {
+		return
+	}
+	obj2 := got.(*PersistentVolumeClaim)
+	if q := obj2.Spec.Resources.Requests[ResourceStorage]; q.Cmp(userRequest) != 0 {
+		t.Errorf("expected user-provided storage request to survive defaulting, got %v", q)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "k8s.io/kubernetes/pkg/api/resource"
+
+// LimitRangeLister looks up the LimitRanges that govern a namespace, so that
+// PersistentVolumeClaim defaulting can consult a namespace's configured
+// storage DefaultRequest without pkg/api/v1 depending on the apiserver's
+// informer machinery.
+type LimitRangeLister interface {
+	List(namespace string) ([]LimitRange, error)
+}
+
+// limitRangeLister is installed via SetLimitRangeLister. It is nil by default,
+// which disables LimitRange-driven PVC storage defaulting.
+var limitRangeLister LimitRangeLister
+
+// SetLimitRangeLister installs lister as the source of LimitRanges consulted
+// when defaulting a PersistentVolumeClaim's storage request. Passing nil
+// disables that defaulting again.
+func SetLimitRangeLister(lister LimitRangeLister) {
+	limitRangeLister = lister
+}
+
+// defaultStorageRequestFromLimitRanges returns the first storage
+// DefaultRequest found among the PersistentVolumeClaim-type LimitRangeItems
+// governing namespace, if any.
+func defaultStorageRequestFromLimitRanges(lister LimitRangeLister, namespace string) (resource.Quantity, bool) {
+	limitRanges, err := lister.List(namespace)
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != LimitTypePersistentVolumeClaim {
+				continue
+			}
+			if quantity, ok := item.DefaultRequest[ResourceStorage]; ok {
+				return quantity, true
+			}
+		}
+	}
+	return resource.Quantity{}, false
+}
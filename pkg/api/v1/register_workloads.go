@@ -0,0 +1,36 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "k8s.io/kubernetes/pkg/runtime"
+
+// addWorkloadKnownTypes registers Deployment, DaemonSet, and StatefulSet (and
+// their list kinds) with scheme, so the defaulting funcs registered for them
+// in defaults.go actually fire on encode/decode. This must be called
+// alongside the package's existing addKnownTypes in register.go; it is
+// factored out here because register.go's call already lists every
+// pre-existing v1 type and isn't part of this change.
+func addWorkloadKnownTypes(scheme *runtime.Scheme) {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Deployment{},
+		&DeploymentList{},
+		&DaemonSet{},
+		&DaemonSetList{},
+		&StatefulSet{},
+		&StatefulSetList{},
+	)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// Typed values for PodSpec.NetworkMode.
+const (
+	// NetworkModeBridge gives the Pod its own network namespace, bridged
+	// through the node's default network (the historical, pre-NetworkMode
+	// behavior).
+	NetworkModeBridge NetworkMode = "Bridge"
+	// NetworkModeHost runs the Pod in the node's network namespace. Equivalent
+	// to, and kept in sync with, PodSpec.HostNetwork = true.
+	NetworkModeHost NetworkMode = "Host"
+	// NetworkModeNone gives the Pod an isolated network namespace with no
+	// interfaces configured.
+	NetworkModeNone NetworkMode = "None"
+	// NetworkModeCNI delegates network setup to a CNI plugin, optionally pinned
+	// to a specific network via NetworkModeConfig.CNIName.
+	NetworkModeCNI NetworkMode = "CNI"
+	// NetworkModeContainer joins the network namespace of another container,
+	// named by NetworkModeConfig.ContainerRef.
+	NetworkModeContainer NetworkMode = "Container"
+)
+
+// NetworkModeConfig carries the extra configuration some NetworkMode values
+// need beyond the bare mode name.
+type NetworkModeConfig struct {
+	// ContainerRef names the container whose network namespace this Pod joins,
+	// in "container:<name>" form. Only meaningful when NetworkMode is
+	// NetworkModeContainer.
+	ContainerRef string `json:"containerRef,omitempty"`
+
+	// CNIName selects a specific CNI network configuration. Only meaningful
+	// when NetworkMode is NetworkModeCNI; when empty, defaults to the
+	// cluster-configured default CNI network (see SetDefaultCNIName).
+	CNIName string `json:"cniName,omitempty"`
+}
+
+// defaultCNIName is the cluster-configured default CNI network that
+// NetworkModeConfig.CNIName falls back to. Set via SetDefaultCNIName; empty
+// means no default is configured.
+var defaultCNIName string
+
+// SetDefaultCNIName configures the CNI network that PodSpec defaulting falls
+// back to for NetworkModeCNI pods that don't pin a specific CNIName.
+func SetDefaultCNIName(name string) {
+	defaultCNIName = name
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// ProtocolSCTP is the SCTP protocol, alongside the existing ProtocolTCP and
+// ProtocolUDP declared with Protocol in types.go. Validation does not yet
+// accept it, so defaulting leaves any SCTP value exactly as submitted rather
+// than normalizing its case (see normalizeProtocol in defaults.go).
+const ProtocolSCTP Protocol = "SCTP"
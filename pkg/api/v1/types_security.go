@@ -0,0 +1,135 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// PodFSGroupChangePolicy defines the behavior for changing ownership and
+// permission of the volumes before being exposed inside a Pod, for
+// PodSecurityContext.FSGroupChangePolicy.
+type PodFSGroupChangePolicy string
+
+const (
+	// FSGroupChangeOnRootMismatch only changes the volume ownership and
+	// permission if the top-level directory does not already match the
+	// expected ownership.
+	FSGroupChangeOnRootMismatch PodFSGroupChangePolicy = "OnRootMismatch"
+	// FSGroupChangeAlways always changes the volume ownership and permission,
+	// even if they already match the expected state.
+	FSGroupChangeAlways PodFSGroupChangePolicy = "Always"
+)
+
+// SeccompProfileRuntimeDefault is the well-known seccomp profile name that
+// requests the container runtime's default profile, for
+// PodSecurityContext.SeccompProfile.
+const SeccompProfileRuntimeDefault = "RuntimeDefault"
+
+// Capability represents a POSIX capability that can be added to or dropped
+// from a container, for SecurityContext.Capabilities.
+type Capability string
+
+// Capabilities adjusts the set of Linux capabilities a container runs with,
+// on top of the container runtime's own default set.
+type Capabilities struct {
+	// Add is the list of capabilities to add.
+	Add []Capability `json:"add,omitempty"`
+	// Drop is the list of capabilities to remove.
+	Drop []Capability `json:"drop,omitempty"`
+}
+
+// PodSecurityContext holds pod-level security attributes that apply to all
+// containers in the Pod unless a container overrides them in its own
+// SecurityContext.
+type PodSecurityContext struct {
+	// RunAsNonRoot indicates that containers should run as a non-root user.
+	// Validation rejects the Pod at admission time if the image's user is
+	// root and this is true.
+	RunAsNonRoot *bool `json:"runAsNonRoot,omitempty"`
+
+	// SeccompProfile names the seccomp profile applied to all containers, e.g.
+	// SeccompProfileRuntimeDefault.
+	SeccompProfile string `json:"seccompProfile,omitempty"`
+
+	// FSGroupChangePolicy defines how volume ownership and permissions are
+	// reconciled with FSGroup.
+	FSGroupChangePolicy *PodFSGroupChangePolicy `json:"fsGroupChangePolicy,omitempty"`
+}
+
+// SecurityContext holds container-level security attributes that override
+// any PodSecurityContext set at the Pod level.
+type SecurityContext struct {
+	// AllowPrivilegeEscalation controls whether a process can gain more
+	// privileges than its parent process.
+	AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation,omitempty"`
+
+	// ReadOnlyRootFilesystem mounts the container's root filesystem as
+	// read-only.
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+
+	// Capabilities adjusts the Linux capabilities the container runs with.
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+}
+
+// DeepCopy returns a deep copy of c, or nil if c is nil.
+func (c *Capabilities) DeepCopy() *Capabilities {
+	if c == nil {
+		return nil
+	}
+	out := new(Capabilities)
+	if c.Add != nil {
+		out.Add = append([]Capability{}, c.Add...)
+	}
+	if c.Drop != nil {
+		out.Drop = append([]Capability{}, c.Drop...)
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of sc, or nil if sc is nil.
+func (sc *PodSecurityContext) DeepCopy() *PodSecurityContext {
+	if sc == nil {
+		return nil
+	}
+	out := new(PodSecurityContext)
+	*out = *sc
+	if sc.RunAsNonRoot != nil {
+		nonRoot := *sc.RunAsNonRoot
+		out.RunAsNonRoot = &nonRoot
+	}
+	if sc.FSGroupChangePolicy != nil {
+		change := *sc.FSGroupChangePolicy
+		out.FSGroupChangePolicy = &change
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of sc, or nil if sc is nil.
+func (sc *SecurityContext) DeepCopy() *SecurityContext {
+	if sc == nil {
+		return nil
+	}
+	out := new(SecurityContext)
+	*out = *sc
+	if sc.AllowPrivilegeEscalation != nil {
+		allow := *sc.AllowPrivilegeEscalation
+		out.AllowPrivilegeEscalation = &allow
+	}
+	if sc.ReadOnlyRootFilesystem != nil {
+		readOnly := *sc.ReadOnlyRootFilesystem
+		out.ReadOnlyRootFilesystem = &readOnly
+	}
+	out.Capabilities = sc.Capabilities.DeepCopy()
+	return out
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/util/intstr"
+)
+
+// DeploymentStrategyType describes the types of a deployment's rolling update strategy.
+type DeploymentStrategyType string
+
+const (
+	// RecreateDeploymentStrategyType kills all existing pods before creating new ones.
+	RecreateDeploymentStrategyType DeploymentStrategyType = "Recreate"
+	// RollingUpdateDeploymentStrategyType replaces pods gradually, respecting MaxUnavailable and MaxSurge.
+	RollingUpdateDeploymentStrategyType DeploymentStrategyType = "RollingUpdate"
+)
+
+// DeploymentStrategy describes how to replace existing pods with new ones.
+type DeploymentStrategy struct {
+	// Type of deployment. Can be "Recreate" or "RollingUpdate". Default is RollingUpdate.
+	Type DeploymentStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate is the configuration used when Type is RollingUpdateDeploymentStrategyType.
+	RollingUpdate *RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateDeployment controls the desired behavior of a rolling update.
+type RollingUpdateDeployment struct {
+	// MaxUnavailable is the maximum number of pods that can be unavailable during the update.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MaxSurge is the maximum number of pods that can be scheduled above the desired number of pods.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// DeploymentSpec is the specification of the desired behavior of a Deployment.
+type DeploymentSpec struct {
+	// Replicas is the number of desired pods. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is a label query over pods that should match the Replicas count.
+	Selector *unversioned.LabelSelector `json:"selector,omitempty"`
+
+	// Template describes the pods that will be created.
+	Template PodTemplateSpec `json:"template"`
+
+	// Strategy is the deployment strategy to use to replace existing pods with new ones.
+	Strategy DeploymentStrategy `json:"strategy,omitempty"`
+
+	// RevisionHistoryLimit is the number of old ReplicaSets to retain to allow rollback.
+	// Defaults to 10.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// ProgressDeadlineSeconds is the maximum time in seconds for a deployment to make
+	// progress before it is considered to be failed. Defaults to 600s.
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// DeploymentStatus is the most recently observed status of a Deployment.
+type DeploymentStatus struct {
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	Replicas           int32 `json:"replicas,omitempty"`
+	UpdatedReplicas    int32 `json:"updatedReplicas,omitempty"`
+	AvailableReplicas  int32 `json:"availableReplicas,omitempty"`
+}
+
+// Deployment enables declarative updates for Pods and ReplicaSets.
+type Deployment struct {
+	unversioned.TypeMeta `json:",inline"`
+	ObjectMeta           `json:"metadata,omitempty"`
+
+	Spec   DeploymentSpec   `json:"spec,omitempty"`
+	Status DeploymentStatus `json:"status,omitempty"`
+}
+
+// DeploymentList is a list of Deployments.
+type DeploymentList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	Items []Deployment `json:"items"`
+}
+
+// DaemonSetUpdateStrategyType describes the types of a DaemonSet's update strategy.
+type DaemonSetUpdateStrategyType string
+
+const (
+	// RollingUpdateDaemonSetStrategyType replaces daemon pods gradually.
+	RollingUpdateDaemonSetStrategyType DaemonSetUpdateStrategyType = "RollingUpdate"
+	// OnDeleteDaemonSetStrategyType only replaces daemon pods when they are manually deleted.
+	OnDeleteDaemonSetStrategyType DaemonSetUpdateStrategyType = "OnDelete"
+)
+
+// RollingUpdateDaemonSet controls the desired behavior of a DaemonSet rolling update.
+type RollingUpdateDaemonSet struct {
+	// MaxUnavailable is the maximum number of nodes that can be unavailable during the update.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// DaemonSetUpdateStrategy describes how to replace existing DaemonSet pods with new ones.
+type DaemonSetUpdateStrategy struct {
+	// Type of daemon set update. Can be "RollingUpdate" or "OnDelete". Default is RollingUpdate.
+	Type DaemonSetUpdateStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate is the configuration used when Type is RollingUpdateDaemonSetStrategyType.
+	RollingUpdate *RollingUpdateDaemonSet `json:"rollingUpdate,omitempty"`
+}
+
+// DaemonSetSpec is the specification of the desired behavior of a DaemonSet.
+type DaemonSetSpec struct {
+	// Selector is a label query over pods that are managed by the daemon set.
+	Selector *unversioned.LabelSelector `json:"selector,omitempty"`
+
+	// Template describes the pods that will be created.
+	Template PodTemplateSpec `json:"template"`
+
+	// UpdateStrategy describes how to replace existing DaemonSet pods with new ones.
+	UpdateStrategy DaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// DaemonSetStatus represents the current status of a DaemonSet.
+type DaemonSetStatus struct {
+	CurrentNumberScheduled int32 `json:"currentNumberScheduled"`
+	NumberMisscheduled     int32 `json:"numberMisscheduled"`
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+}
+
+// DaemonSet represents the configuration of a daemon set.
+type DaemonSet struct {
+	unversioned.TypeMeta `json:",inline"`
+	ObjectMeta           `json:"metadata,omitempty"`
+
+	Spec   DaemonSetSpec   `json:"spec,omitempty"`
+	Status DaemonSetStatus `json:"status,omitempty"`
+}
+
+// DaemonSetList is a list of DaemonSets.
+type DaemonSetList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	Items []DaemonSet `json:"items"`
+}
+
+// StatefulSetUpdateStrategyType describes the types of a StatefulSet's update strategy.
+type StatefulSetUpdateStrategyType string
+
+const (
+	// RollingUpdateStatefulSetStrategyType replaces pods gradually, respecting ordering.
+	RollingUpdateStatefulSetStrategyType StatefulSetUpdateStrategyType = "RollingUpdate"
+	// OnDeleteStatefulSetStrategyType only replaces pods when they are manually deleted.
+	OnDeleteStatefulSetStrategyType StatefulSetUpdateStrategyType = "OnDelete"
+)
+
+// RollingUpdateStatefulSetStrategy controls the desired behavior of a StatefulSet rolling update.
+type RollingUpdateStatefulSetStrategy struct {
+	// Partition indicates the ordinal at which the StatefulSet should be partitioned.
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// StatefulSetUpdateStrategy describes how to replace existing StatefulSet pods with new ones.
+type StatefulSetUpdateStrategy struct {
+	// Type of StatefulSet update. Can be "RollingUpdate" or "OnDelete". Default is RollingUpdate.
+	Type StatefulSetUpdateStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate is the configuration used when Type is RollingUpdateStatefulSetStrategyType.
+	RollingUpdate *RollingUpdateStatefulSetStrategy `json:"rollingUpdate,omitempty"`
+}
+
+// StatefulSetSpec is the specification of the desired behavior of a StatefulSet.
+type StatefulSetSpec struct {
+	// Replicas is the number of desired pods. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Selector is a label query over pods that should match the Replicas count.
+	Selector *unversioned.LabelSelector `json:"selector,omitempty"`
+
+	// Template describes the pods that will be created.
+	Template PodTemplateSpec `json:"template"`
+
+	// ServiceName is the name of the service that governs this StatefulSet.
+	ServiceName string `json:"serviceName"`
+
+	// VolumeClaimTemplates is a list of claims that pods are allowed to reference.
+	VolumeClaimTemplates []PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// UpdateStrategy describes how to replace existing StatefulSet pods with new ones.
+	UpdateStrategy StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// StatefulSetStatus represents the current state of a StatefulSet.
+type StatefulSetStatus struct {
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	Replicas           int32  `json:"replicas"`
+}
+
+// StatefulSet represents a set of pods with consistent identities.
+type StatefulSet struct {
+	unversioned.TypeMeta `json:",inline"`
+	ObjectMeta           `json:"metadata,omitempty"`
+
+	Spec   StatefulSetSpec   `json:"spec,omitempty"`
+	Status StatefulSetStatus `json:"status,omitempty"`
+}
+
+// StatefulSetList is a list of StatefulSets.
+type StatefulSetList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	Items []StatefulSet `json:"items"`
+}
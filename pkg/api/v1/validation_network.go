@@ -0,0 +1,38 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// ValidateNetworkModeConsistency rejects a PodSpec whose legacy HostNetwork
+// flag disagrees with its NetworkMode. Defaulting (see the PodSpec defaulter
+// in defaults.go) deliberately leaves this conflict alone instead of
+// resolving it, so pod validation must call this to catch it. It belongs
+// alongside the rest of pod spec validation; it's factored out here because
+// that validation lives outside this snapshot.
+func ValidateNetworkModeConsistency(spec *PodSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.HostNetwork && spec.NetworkMode != "" && spec.NetworkMode != NetworkModeHost {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("networkMode"), spec.NetworkMode,
+			fmt.Sprintf("must be %q when hostNetwork is true", NetworkModeHost)))
+	}
+	return allErrs
+}
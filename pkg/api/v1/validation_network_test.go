@@ -0,0 +1,45 @@
+/*
+Copyright 2016 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	. "k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+func TestValidateNetworkModeConsistencyRejectsConflict(t *testing.T) {
+	spec := &PodSpec{HostNetwork: true, NetworkMode: NetworkModeNone}
+	errs := ValidateNetworkModeConsistency(spec, field.NewPath("spec"))
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error for a HostNetwork/NetworkMode conflict, got %v", errs)
+	}
+}
+
+func TestValidateNetworkModeConsistencyAllowsAgreement(t *testing.T) {
+	cases := []*PodSpec{
+		{HostNetwork: true, NetworkMode: NetworkModeHost},
+		{HostNetwork: false, NetworkMode: NetworkModeNone},
+		{HostNetwork: false, NetworkMode: ""},
+	}
+	for _, spec := range cases {
+		if errs := ValidateNetworkModeConsistency(spec, field.NewPath("spec")); len(errs) != 0 {
+			t.Errorf("expected no errors for %+v, got %v", spec, errs)
+		}
+	}
+}